@@ -0,0 +1,119 @@
+package bolt
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Binder decodes the request body (or query/form values) into i.
+type Binder interface {
+	Bind(i interface{}, c *Context) error
+}
+
+// defaultBinder dispatches on the request's Content-Type, following the
+// pattern: JSON and XML bodies are decoded directly, form and multipart
+// bodies are mapped onto struct fields via their `form` tag, and GET/DELETE
+// requests with no body are bound from the query string.
+type defaultBinder struct{}
+
+func (b *defaultBinder) Bind(i interface{}, c *Context) error {
+	req := c.Request
+
+	if req.ContentLength == 0 {
+		if req.Method == http.MethodGet || req.Method == http.MethodDelete {
+			return b.bindData(i, req.URL.Query())
+		}
+		return errors.New("bolt: request body can't be empty")
+	}
+
+	ctype := req.Header.Get(HeaderContentType)
+	switch {
+	case strings.HasPrefix(ctype, MIMEJSON):
+		return json.NewDecoder(req.Body).Decode(i)
+	case strings.HasPrefix(ctype, MIMEXML):
+		return xml.NewDecoder(req.Body).Decode(i)
+	case strings.HasPrefix(ctype, MIMEMultipartForm):
+		if err := req.ParseMultipartForm(32 << 20); err != nil {
+			return err
+		}
+		return b.bindData(i, req.Form)
+	case strings.HasPrefix(ctype, MIMEForm):
+		if err := req.ParseForm(); err != nil {
+			return err
+		}
+		return b.bindData(i, req.Form)
+	}
+
+	return errors.New("bolt: unsupported media type " + ctype)
+}
+
+// bindData maps url.Values onto the exported fields of the struct pointed
+// to by ptr, using each field's `form` tag (or its name) as the key.
+func (b *defaultBinder) bindData(ptr interface{}, data url.Values) error {
+	typ := reflect.TypeOf(ptr).Elem()
+	val := reflect.ValueOf(ptr).Elem()
+	if typ.Kind() != reflect.Struct {
+		return errors.New("bolt: binding element must be a struct")
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := val.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		name := typ.Field(i).Tag.Get("form")
+		if name == "" {
+			name = typ.Field(i).Name
+		}
+
+		value := data.Get(name)
+		if value == "" {
+			continue
+		}
+
+		if err := setFieldValue(field, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setFieldValue(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		n, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(n)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package bolt
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type widget struct {
+	Name  string `form:"name"`
+	Count int    `form:"count"`
+}
+
+func TestDefaultBinderBindsJSONBody(t *testing.T) {
+	b := New()
+	var got widget
+	b.Post("/widgets", func(c *Context) {
+		if err := c.Bind(&got); err != nil {
+			t.Fatalf("Bind returned %v", err)
+		}
+	})
+
+	req := httptest.NewRequest("POST", "/widgets", strings.NewReader(`{"Name":"gear","Count":3}`))
+	req.Header.Set(HeaderContentType, MIMEJSON)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if got.Name != "gear" || got.Count != 3 {
+		t.Errorf("got %+v, want {gear 3}", got)
+	}
+}
+
+func TestDefaultBinderBindsFormBody(t *testing.T) {
+	b := New()
+	var got widget
+	b.Post("/widgets", func(c *Context) {
+		if err := c.Bind(&got); err != nil {
+			t.Fatalf("Bind returned %v", err)
+		}
+	})
+
+	req := httptest.NewRequest("POST", "/widgets", strings.NewReader("name=gear&count=3"))
+	req.Header.Set(HeaderContentType, MIMEForm)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if got.Name != "gear" || got.Count != 3 {
+		t.Errorf("got %+v, want {gear 3}", got)
+	}
+}
+
+type upperRenderer struct{}
+
+func (upperRenderer) Render(w io.Writer, name string, data interface{}, c *Context) error {
+	_, err := io.WriteString(w, strings.ToUpper(name+":"+data.(string)))
+	return err
+}
+
+func TestRenderWritesThroughRegisteredRenderer(t *testing.T) {
+	b := New(SetRenderer(upperRenderer{}))
+	b.Get("/greet", func(c *Context) {
+		if err := c.Render(200, "hello", "world"); err != nil {
+			t.Fatalf("Render returned %v", err)
+		}
+	})
+
+	req := httptest.NewRequest("GET", "/greet", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Body.String() != "HELLO:WORLD" {
+		t.Errorf("body = %q, want %q", rw.Body.String(), "HELLO:WORLD")
+	}
+}
+
+func TestRenderWithoutRendererReturnsError(t *testing.T) {
+	b := New()
+	var renderErr error
+	b.Get("/greet", func(c *Context) {
+		renderErr = c.Render(200, "hello", "world")
+	})
+
+	req := httptest.NewRequest("GET", "/greet", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if renderErr == nil {
+		t.Error("Render with no registered Renderer should return an error")
+	}
+}
@@ -1,9 +1,12 @@
 package bolt
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 )
 
 type (
@@ -14,6 +17,19 @@ type (
 		notFoundHandler            HandlerFunc
 		methodNotAllowedHandler    HandlerFunc
 		internalServerErrorHandler HandlerFunc
+		redirectTrailingSlash      bool
+		redirectFixedPath          bool
+		handleOPTIONS              bool
+		globalOPTIONS              HandlerFunc
+		panicHandler               func(*Context, interface{})
+		readTimeout                time.Duration
+		writeTimeout               time.Duration
+		idleTimeout                time.Duration
+		onShutdown                 []func()
+		serverMu                   sync.Mutex
+		server                     *http.Server
+		binder                     Binder
+		renderer                   Renderer
 		pool                       sync.Pool
 	}
 	// Option is used to configure bolt. They are passed while creating a new
@@ -23,9 +39,14 @@ type (
 )
 
 const (
-	MIMEJSON = "application/json"
+	MIMEJSON          = "application/json"
+	MIMEXML           = "application/xml"
+	MIMEHTML          = "text/html"
+	MIMEForm          = "application/x-www-form-urlencoded"
+	MIMEMultipartForm = "multipart/form-data"
 
 	HeaderAccept             = "Accept"
+	HeaderAllow              = "Allow"
 	HeaderContentDisposition = "Content-Disposition"
 	HeaderContentLength      = "Content-Length"
 	HeaderContentType        = "Content-Type"
@@ -46,7 +67,14 @@ var MethodMap = map[string]uint8{
 // New creates a bolt instance with options.
 func New(opts ...Option) (b *Bolt) {
 	b = &Bolt{
-		maxParam: 5,
+		maxParam:              5,
+		redirectTrailingSlash: true,
+		redirectFixedPath:     true,
+		handleOPTIONS:         true,
+		binder:                &defaultBinder{},
+		globalOPTIONS: func(c *Context) {
+			c.NoContent(http.StatusOK)
+		},
 		notFoundHandler: func(c *Context) {
 			http.Error(c.Response, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 			c.Halt()
@@ -95,6 +123,91 @@ func NotFoundHandler(h HandlerFunc) Option {
 	}
 }
 
+// RedirectTrailingSlash returns an option to enable or disable automatic
+// redirection when a path is requested that differs from a registered route
+// only in a trailing slash. It is enabled by default.
+func RedirectTrailingSlash(enabled bool) Option {
+	return func(b *Bolt) {
+		b.redirectTrailingSlash = enabled
+	}
+}
+
+// RedirectFixedPath returns an option to enable or disable automatic
+// redirection to the canonical path when a request is otherwise unmatched
+// due to extra slashes or case differences. It is enabled by default.
+func RedirectFixedPath(enabled bool) Option {
+	return func(b *Bolt) {
+		b.redirectFixedPath = enabled
+	}
+}
+
+// HandleOPTIONS returns an option to enable or disable automatically
+// answering OPTIONS requests with the Allow header for methods registered
+// at that path. It is enabled by default.
+func HandleOPTIONS(enabled bool) Option {
+	return func(b *Bolt) {
+		b.handleOPTIONS = enabled
+	}
+}
+
+// GlobalOPTIONS returns an option to set the handler invoked for automatic
+// OPTIONS requests, after the Allow header has been set. The default simply
+// replies with 200 and no body.
+func GlobalOPTIONS(h HandlerFunc) Option {
+	return func(b *Bolt) {
+		b.globalOPTIONS = h
+	}
+}
+
+// PanicHandler returns an option to set a handler invoked when a request
+// handler panics. It receives the recovered value and is responsible for
+// logging it and rendering a response; if unset, a panic falls back to the
+// InternalServerErrorHandler.
+func PanicHandler(h func(*Context, interface{})) Option {
+	return func(b *Bolt) {
+		b.panicHandler = h
+	}
+}
+
+// ReadTimeout returns an option to set the underlying http.Server's
+// ReadTimeout.
+func ReadTimeout(d time.Duration) Option {
+	return func(b *Bolt) {
+		b.readTimeout = d
+	}
+}
+
+// WriteTimeout returns an option to set the underlying http.Server's
+// WriteTimeout.
+func WriteTimeout(d time.Duration) Option {
+	return func(b *Bolt) {
+		b.writeTimeout = d
+	}
+}
+
+// IdleTimeout returns an option to set the underlying http.Server's
+// IdleTimeout.
+func IdleTimeout(d time.Duration) Option {
+	return func(b *Bolt) {
+		b.idleTimeout = d
+	}
+}
+
+// SetBinder returns an option to set a custom Binder, replacing the default
+// Content-Type-based binder.
+func SetBinder(b Binder) Option {
+	return func(bolt *Bolt) {
+		bolt.binder = b
+	}
+}
+
+// SetRenderer returns an option to set the Renderer used by Context.Render.
+func SetRenderer(r Renderer) Option {
+	return func(b *Bolt) {
+		b.renderer = r
+	}
+}
+
 // MethodNotAllowedHandler returns an option to set a custom MethodNotAllowed
 // handler.
 func MethodNotAllowedHandler(h HandlerFunc) Option {
@@ -116,6 +229,12 @@ func (b *Bolt) Use(h ...HandlerFunc) {
 	b.handlers = append(b.handlers, h...)
 }
 
+// OnShutdown registers a hook to run when the server begins a graceful
+// shutdown, letting middleware (DB pools, etc.) clean up after themselves.
+func (b *Bolt) OnShutdown(f func()) {
+	b.onShutdown = append(b.onShutdown, f)
+}
+
 // Connect adds CONNECT route.
 func (b *Bolt) Connect(path string, h ...HandlerFunc) {
 	b.Handle("CONNECT", path, h)
@@ -163,7 +282,11 @@ func (b *Bolt) Trace(path string, h ...HandlerFunc) {
 
 // Handle adds method, path and handler to the router.
 func (b *Bolt) Handle(method, path string, h []HandlerFunc) {
-	h = append(b.handlers, h...)
+	b.add(method, path, append(b.handlers, h...))
+}
+
+// add registers the fully composed handler chain for method and path.
+func (b *Bolt) add(method, path string, h []HandlerFunc) {
 	l := len(h)
 	b.Router.Add(method, path, func(c *Context) {
 		c.handlers = h
@@ -172,10 +295,20 @@ func (b *Bolt) Handle(method, path string, h []HandlerFunc) {
 	})
 }
 
+// Group creates a new Group of routes under prefix, with middleware layered
+// on top of the Bolt-wide middleware chain.
+func (b *Bolt) Group(prefix string, middleware ...HandlerFunc) *Group {
+	return &Group{
+		bolt:       b,
+		prefix:     prefix,
+		middleware: middleware,
+	}
+}
+
 // Static serves static files.
 func (b *Bolt) Static(path, root string) {
 	fs := http.StripPrefix(path, http.FileServer(http.Dir(root)))
-	b.Get(path+"/*", func(c *Context) {
+	b.Get(path+"/*filepath", func(c *Context) {
 		fs.ServeHTTP(c.Response, c.Request)
 	})
 }
@@ -196,22 +329,145 @@ func (b *Bolt) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	// Find and execute handler
 	h, c, s := b.Router.Find(r.Method, r.URL.Path)
 	c.reset(rw, r)
-	if h != nil {
+	defer b.recover(c)
+	switch {
+	case h != nil:
 		h(c)
-	} else {
-		if s == NotFound {
-			b.notFoundHandler(c)
-		} else if s == NotAllowed {
-			b.methodNotAllowedHandler(c)
-		}
+	case s == NotFound && b.redirect(c):
+		// request was redirected to its canonical path
+	case s == NotFound:
+		b.notFoundHandler(c)
+	case s == NotAllowed:
+		b.serveNotAllowed(c)
 	}
 	b.pool.Put(c)
 }
 
+// recover catches a panic from request dispatch, hands it to the
+// PanicHandler (or InternalServerErrorHandler by default) and returns the
+// Context to the pool, which the normal return path would otherwise have
+// skipped.
+func (b *Bolt) recover(c *Context) {
+	if rcv := recover(); rcv != nil {
+		if b.panicHandler != nil {
+			b.panicHandler(c, rcv)
+		} else {
+			b.internalServerErrorHandler(c)
+		}
+		b.pool.Put(c)
+	}
+}
+
+// serveNotAllowed sets the Allow header for the methods registered at the
+// requested path and either answers an automatic OPTIONS request or
+// delegates to the MethodNotAllowedHandler.
+func (b *Bolt) serveNotAllowed(c *Context) {
+	allowed := b.Router.Allowed(c.Request.URL.Path)
+
+	if c.Request.Method == http.MethodOptions && b.handleOPTIONS {
+		allowed = appendIfMissing(allowed, http.MethodOptions)
+		c.Response.Header().Set(HeaderAllow, strings.Join(allowed, ", "))
+		b.globalOPTIONS(c)
+		return
+	}
+
+	c.Response.Header().Set(HeaderAllow, strings.Join(allowed, ", "))
+	b.methodNotAllowedHandler(c)
+}
+
+func appendIfMissing(methods []string, method string) []string {
+	for _, m := range methods {
+		if m == method {
+			return methods
+		}
+	}
+	return append(methods, method)
+}
+
+// redirect attempts to resolve a NotFound request to a canonical path via
+// RedirectTrailingSlash / RedirectFixedPath, issuing the redirect and
+// returning true if one applies.
+func (b *Bolt) redirect(c *Context) bool {
+	method, path := c.Request.Method, c.Request.URL.Path
+
+	if b.redirectTrailingSlash {
+		if fixed, ok := b.Router.redirectTrailingSlash(method, path); ok {
+			b.redirectTo(c, fixed)
+			return true
+		}
+	}
+
+	if b.redirectFixedPath {
+		if fixed, ok := b.Router.redirectFixedPath(method, path); ok {
+			b.redirectTo(c, fixed)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *Bolt) redirectTo(c *Context, path string) {
+	code := http.StatusMovedPermanently
+	if c.Request.Method != "GET" {
+		code = http.StatusPermanentRedirect
+	}
+	if q := c.Request.URL.RawQuery; q != "" {
+		path += "?" + q
+	}
+	http.Redirect(c.Response, c.Request, path, code)
+	c.Halt()
+}
+
+// Run starts the server listening on addr. It blocks until the server stops,
+// logging and exiting the process on any error other than a graceful Stop.
 func (b *Bolt) Run(addr string) {
-	log.Fatal(http.ListenAndServe(addr, b))
+	srv := b.newServer(addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
 }
 
-func (b *Bolt) Stop(addr string) {
-	panic("implement it")
+// RunTLS starts the server listening on addr using the given certificate and
+// key files. It blocks the same way Run does.
+func (b *Bolt) RunTLS(addr, certFile, keyFile string) {
+	srv := b.newServer(addr)
+	if err := srv.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
+
+// newServer builds the underlying http.Server and stores it under serverMu
+// so a concurrent Stop can see it safely.
+func (b *Bolt) newServer(addr string) *http.Server {
+	srv := &http.Server{
+		Addr:         addr,
+		Handler:      b,
+		ReadTimeout:  b.readTimeout,
+		WriteTimeout: b.writeTimeout,
+		IdleTimeout:  b.idleTimeout,
+	}
+	for _, f := range b.onShutdown {
+		srv.RegisterOnShutdown(f)
+	}
+
+	b.serverMu.Lock()
+	b.server = srv
+	b.serverMu.Unlock()
+
+	return srv
+}
+
+// Stop gracefully shuts the server down, waiting for in-flight requests to
+// complete or ctx to expire. It is safe to call concurrently with Run or
+// RunTLS, e.g. from a signal handler.
+func (b *Bolt) Stop(ctx context.Context) error {
+	b.serverMu.Lock()
+	srv := b.server
+	b.serverMu.Unlock()
+
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
 }
\ No newline at end of file
@@ -0,0 +1,130 @@
+package bolt
+
+import (
+	"errors"
+	"net/http"
+)
+
+type (
+	// Context wraps the request and response for a single HTTP call and
+	// carries path params, a per-request store and the middleware chain.
+	// Contexts are pooled, so never retain one past the handler it was
+	// passed to.
+	Context struct {
+		Request  *http.Request
+		Response *response
+		params   Params
+		store    store
+		handlers []HandlerFunc
+		i        int
+		l        int
+		bolt     *Bolt
+	}
+
+	// Param is a single matched path parameter.
+	Param struct {
+		Key   string
+		Value string
+	}
+
+	// Params is the collection of matched path parameters for a request.
+	Params []Param
+
+	store map[string]interface{}
+)
+
+// Get returns the value of the named param, or an empty string if it wasn't
+// matched.
+func (ps Params) Get(name string) string {
+	for _, p := range ps {
+		if p.Key == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// Param returns the value of the named path param.
+func (c *Context) Param(name string) string {
+	return c.params.Get(name)
+}
+
+// Query returns the value of the named query string param.
+func (c *Context) Query(name string) string {
+	return c.Request.URL.Query().Get(name)
+}
+
+// Form returns the value of the named form param.
+func (c *Context) Form(name string) string {
+	return c.Request.FormValue(name)
+}
+
+// Get retrieves data from the context store.
+func (c *Context) Get(key string) interface{} {
+	return c.store[key]
+}
+
+// Set saves data in the context store, making it available to downstream
+// handlers in the chain.
+func (c *Context) Set(key string, val interface{}) {
+	if c.store == nil {
+		c.store = make(store)
+	}
+	c.store[key] = val
+}
+
+// Next executes the next handler in the chain.
+func (c *Context) Next() {
+	c.i++
+	if c.i < c.l {
+		c.handlers[c.i](c)
+	}
+}
+
+// Halt stops the middleware chain from continuing to the next handler.
+func (c *Context) Halt() {
+	c.i = c.l
+}
+
+// NoContent sends a response with no body.
+func (c *Context) NoContent(code int) error {
+	c.Response.WriteHeader(code)
+	return nil
+}
+
+// String sends a plain text response with the given status code.
+func (c *Context) String(code int, s string) error {
+	c.Response.Header().Set(HeaderContentType, "text/plain; charset=utf-8")
+	c.Response.WriteHeader(code)
+	_, err := c.Response.Write([]byte(s))
+	return err
+}
+
+// Bind decodes the request into i using the Bolt instance's Binder.
+func (c *Context) Bind(i interface{}) error {
+	return c.bolt.binder.Bind(i, c)
+}
+
+// Render renders the named template with data using the Bolt instance's
+// Renderer, writing the given status code.
+func (c *Context) Render(code int, name string, data interface{}) error {
+	if c.bolt.renderer == nil {
+		return errors.New("bolt: renderer not registered")
+	}
+	c.Response.Header().Set(HeaderContentType, MIMEHTML+"; charset=utf-8")
+	c.Response.WriteHeader(code)
+	return c.bolt.renderer.Render(c.Response, name, data, c)
+}
+
+// reset rebinds the Context to a new request/response pair, clearing any
+// state left over from its previous use in the pool.
+func (c *Context) reset(rw http.ResponseWriter, r *http.Request) {
+	c.Request = r
+	c.Response.reset(rw)
+	for k := range c.store {
+		delete(c.store, k)
+	}
+	c.handlers = nil
+	c.i = -1
+	c.l = 0
+}
@@ -0,0 +1,83 @@
+package bolt
+
+// Group is a set of routes sharing a common path prefix and an additional
+// middleware chain layered on top of the Bolt it was created from. Groups
+// may be nested via Group.Group.
+type Group struct {
+	bolt       *Bolt
+	prefix     string
+	middleware []HandlerFunc
+}
+
+// Group creates a sub-group under prefix, inheriting g's prefix and
+// middleware and extending both.
+func (g *Group) Group(prefix string, middleware ...HandlerFunc) *Group {
+	m := make([]HandlerFunc, 0, len(g.middleware)+len(middleware))
+	m = append(m, g.middleware...)
+	m = append(m, middleware...)
+	return &Group{
+		bolt:       g.bolt,
+		prefix:     g.prefix + prefix,
+		middleware: m,
+	}
+}
+
+// Use adds middleware(s) to the group's chain.
+func (g *Group) Use(h ...HandlerFunc) {
+	g.middleware = append(g.middleware, h...)
+}
+
+// Handle adds method, path and handler to the group, composing
+// bolt.handlers + group.middleware + h into the final chain.
+func (g *Group) Handle(method, path string, h []HandlerFunc) {
+	chain := make([]HandlerFunc, 0, len(g.bolt.handlers)+len(g.middleware)+len(h))
+	chain = append(chain, g.bolt.handlers...)
+	chain = append(chain, g.middleware...)
+	chain = append(chain, h...)
+	g.bolt.add(method, g.prefix+path, chain)
+}
+
+// Connect adds CONNECT route.
+func (g *Group) Connect(path string, h ...HandlerFunc) {
+	g.Handle("CONNECT", path, h)
+}
+
+// Delete adds DELETE route.
+func (g *Group) Delete(path string, h ...HandlerFunc) {
+	g.Handle("DELETE", path, h)
+}
+
+// Get adds GET route.
+func (g *Group) Get(path string, h ...HandlerFunc) {
+	g.Handle("GET", path, h)
+}
+
+// Head adds HEAD route.
+func (g *Group) Head(path string, h ...HandlerFunc) {
+	g.Handle("HEAD", path, h)
+}
+
+// Options adds OPTIONS route.
+func (g *Group) Options(path string, h ...HandlerFunc) {
+	g.Handle("OPTIONS", path, h)
+}
+
+// Patch adds PATCH route.
+func (g *Group) Patch(path string, h ...HandlerFunc) {
+	g.Handle("PATCH", path, h)
+}
+
+// Post adds POST route.
+func (g *Group) Post(path string, h ...HandlerFunc) {
+	g.Handle("POST", path, h)
+}
+
+// Put adds PUT route.
+func (g *Group) Put(path string, h ...HandlerFunc) {
+	g.Handle("PUT", path, h)
+}
+
+// Trace adds TRACE route.
+func (g *Group) Trace(path string, h ...HandlerFunc) {
+	g.Handle("TRACE", path, h)
+}
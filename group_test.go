@@ -0,0 +1,74 @@
+package bolt
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGroupComposesMiddlewareAndPrefix(t *testing.T) {
+	b := New()
+
+	var order []string
+	b.Use(func(c *Context) {
+		order = append(order, "bolt")
+		c.Next()
+	})
+
+	api := b.Group("/api", func(c *Context) {
+		order = append(order, "group")
+		c.Next()
+	})
+	api.Get("/widgets", func(c *Context) {
+		order = append(order, "handler")
+	})
+
+	req := httptest.NewRequest("GET", "/api/widgets", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	want := []string{"bolt", "group", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("handler order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("handler order = %v, want %v", order, want)
+			break
+		}
+	}
+}
+
+func TestNestedGroupInheritsPrefixAndMiddleware(t *testing.T) {
+	b := New()
+
+	var order []string
+	api := b.Group("/api", func(c *Context) {
+		order = append(order, "api")
+		c.Next()
+	})
+	v1 := api.Group("/v1", func(c *Context) {
+		order = append(order, "v1")
+		c.Next()
+	})
+	v1.Get("/widgets", func(c *Context) {
+		order = append(order, "handler")
+	})
+
+	req := httptest.NewRequest("GET", "/api/v1/widgets", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Code != 200 {
+		t.Fatalf("got status %d, want 200", rw.Code)
+	}
+	want := []string{"api", "v1", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("handler order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("handler order = %v, want %v", order, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package bolt
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPanicHandlerRecoversAndPoolStillServesContexts(t *testing.T) {
+	var recovered interface{}
+	b := New(PanicHandler(func(c *Context, rcv interface{}) {
+		recovered = rcv
+		c.String(500, "boom")
+	}))
+	b.Get("/explode", func(c *Context) {
+		panic("kaboom")
+	})
+
+	req := httptest.NewRequest("GET", "/explode", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if recovered != "kaboom" {
+		t.Fatalf("PanicHandler received %v, want %q", recovered, "kaboom")
+	}
+	if rw.Code != 500 {
+		t.Fatalf("got status %d, want 500", rw.Code)
+	}
+
+	// sync.Pool does not promise that a Context put back by the recovery
+	// path is the one a later Get returns, so this only checks that the
+	// pool still hands out a Context after a panicking request.
+	c := b.pool.Get().(*Context)
+	if c == nil {
+		t.Fatal("pool.Get returned nil after a panicking request")
+	}
+}
+
+func TestPanicWithoutHandlerUsesInternalServerErrorHandler(t *testing.T) {
+	b := New()
+	b.Get("/explode", func(c *Context) {
+		panic("kaboom")
+	})
+
+	req := httptest.NewRequest("GET", "/explode", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Code != 500 {
+		t.Fatalf("got status %d, want 500", rw.Code)
+	}
+}
@@ -0,0 +1,9 @@
+package bolt
+
+import "io"
+
+// Renderer renders a named template with data, writing the result to w. It
+// lets users plug in html/template, pongo2, or any other template engine.
+type Renderer interface {
+	Render(w io.Writer, name string, data interface{}, c *Context) error
+}
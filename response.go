@@ -0,0 +1,42 @@
+package bolt
+
+import "net/http"
+
+// response wraps http.ResponseWriter and keeps track of its status code and
+// the number of bytes written so middleware can inspect them after a
+// handler runs.
+type response struct {
+	http.ResponseWriter
+	status    int
+	size      int
+	committed bool
+}
+
+// WriteHeader writes the status code, but only the first time it is called.
+func (r *response) WriteHeader(code int) {
+	if r.committed {
+		return
+	}
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+	r.committed = true
+}
+
+// Write writes the data, committing a 200 status if none was set yet.
+func (r *response) Write(b []byte) (n int, err error) {
+	if !r.committed {
+		r.WriteHeader(http.StatusOK)
+	}
+	n, err = r.ResponseWriter.Write(b)
+	r.size += n
+	return
+}
+
+// reset rebinds the response to a new underlying http.ResponseWriter, ready
+// for reuse from the Context pool.
+func (r *response) reset(w http.ResponseWriter) {
+	r.ResponseWriter = w
+	r.status = http.StatusOK
+	r.size = 0
+	r.committed = false
+}
@@ -0,0 +1,521 @@
+package bolt
+
+import (
+	"sort"
+	"strings"
+)
+
+// Status reports how a lookup against the router resolved.
+type Status uint8
+
+const (
+	// Found means a handler was matched for the method and path.
+	Found Status = iota
+	// NotFound means no path in the tree matched.
+	NotFound
+	// NotAllowed means the path matched, but not for the requested method.
+	NotAllowed
+)
+
+type nodeType uint8
+
+const (
+	staticNode nodeType = iota
+	rootNode
+	paramNode
+	catchAllNode
+)
+
+// node is one level of a compressed radix tree: path holds the prefix this
+// node represents, indices holds the first byte of each static child (for
+// O(1) branch selection) and priority, the number of routes registered
+// through a node, keeps the children with the most routes beneath them near
+// the front of their parent's list.
+type node struct {
+	path      string
+	indices   string
+	wildChild bool
+	nType     nodeType
+	priority  uint32
+	children  []*node
+	handler   HandlerFunc
+}
+
+// router resolves an HTTP method and path to a registered HandlerFunc. Each
+// method gets its own radix tree, mirroring httprouter.
+type router struct {
+	trees map[string]*node
+	bolt  *Bolt
+}
+
+// NewRouter creates a router bound to b.
+func NewRouter(b *Bolt) *router {
+	return &router{
+		trees: make(map[string]*node),
+		bolt:  b,
+	}
+}
+
+// Add registers h for method and path. path must start with "/" and may
+// contain ":name" params and a single trailing "*name" catch-all.
+func (r *router) Add(method, path string, h HandlerFunc) {
+	root := r.trees[method]
+	if root == nil {
+		root = &node{nType: rootNode}
+		r.trees[method] = root
+	}
+	root.addRoute(path, h)
+}
+
+// incrementChildPrio bumps the priority of the child at pos, reordering it
+// (and the matching index byte) earlier among its siblings if warranted.
+func (n *node) incrementChildPrio(pos int) int {
+	n.children[pos].priority++
+	prio := n.children[pos].priority
+
+	newPos := pos
+	for newPos > 0 && n.children[newPos-1].priority < prio {
+		n.children[newPos-1], n.children[newPos] = n.children[newPos], n.children[newPos-1]
+		newPos--
+	}
+
+	if newPos != pos {
+		n.indices = n.indices[:newPos] + n.indices[pos:pos+1] + n.indices[newPos:pos] + n.indices[pos+1:]
+	}
+
+	return newPos
+}
+
+func (n *node) addRoute(path string, h HandlerFunc) {
+	fullPath := path
+	n.priority++
+
+	if len(n.path) == 0 && len(n.children) == 0 {
+		n.insertChild(path, fullPath, h)
+		return
+	}
+
+walk:
+	for {
+		i := longestCommonPrefix(path, n.path)
+
+		// Split this node's edge if the new path diverges partway through it.
+		if i < len(n.path) {
+			child := node{
+				path:      n.path[i:],
+				wildChild: n.wildChild,
+				nType:     staticNode,
+				indices:   n.indices,
+				children:  n.children,
+				handler:   n.handler,
+				priority:  n.priority - 1,
+			}
+
+			n.children = []*node{&child}
+			n.indices = string(n.path[i])
+			n.path = path[:i]
+			n.handler = nil
+			n.wildChild = false
+		}
+
+		if i < len(path) {
+			path = path[i:]
+
+			if n.wildChild {
+				n = n.children[0]
+				n.priority++
+
+				if len(path) >= len(n.path) && n.path == path[:len(n.path)] &&
+					n.nType != catchAllNode &&
+					(len(n.path) >= len(path) || path[len(n.path)] == '/') {
+					continue walk
+				}
+				panic("bolt: path segment conflicts with existing wildcard '" + n.path + "' in path '" + fullPath + "'")
+			}
+
+			c := path[0]
+
+			if n.nType == paramNode && c == '/' && len(n.children) == 1 {
+				n = n.children[0]
+				n.priority++
+				continue walk
+			}
+
+			for i, max := 0, len(n.indices); i < max; i++ {
+				if c == n.indices[i] {
+					i = n.incrementChildPrio(i)
+					n = n.children[i]
+					continue walk
+				}
+			}
+
+			if c != ':' && c != '*' {
+				n.indices += string(c)
+				child := &node{}
+				n.children = append(n.children, child)
+				n.incrementChildPrio(len(n.indices) - 1)
+				n = child
+			}
+			n.insertChild(path, fullPath, h)
+			return
+		}
+
+		if n.handler != nil {
+			panic("bolt: a handler is already registered for path '" + fullPath + "'")
+		}
+		n.handler = h
+		return
+	}
+}
+
+func longestCommonPrefix(a, b string) int {
+	i, max := 0, len(a)
+	if len(b) < max {
+		max = len(b)
+	}
+	for i < max && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// insertChild creates the (possibly several) nodes needed to hold the
+// remainder of path, splitting out :param and *catchAll segments.
+func (n *node) insertChild(path, fullPath string, h HandlerFunc) {
+	for {
+		wildcard, i, valid := findWildcard(path)
+		if i < 0 {
+			break
+		}
+
+		if !valid {
+			panic("bolt: only one wildcard per path segment is allowed, has '" + wildcard + "' in path '" + fullPath + "'")
+		}
+		if len(wildcard) < 2 {
+			panic("bolt: wildcards must be named with a non-empty name in path '" + fullPath + "'")
+		}
+		if len(n.children) > 0 {
+			panic("bolt: wildcard segment '" + wildcard + "' conflicts with existing children in path '" + fullPath + "'")
+		}
+
+		if wildcard[0] == ':' {
+			if i > 0 {
+				n.path = path[:i]
+				path = path[i:]
+			}
+
+			child := &node{nType: paramNode, path: wildcard}
+			n.children = []*node{child}
+			n.wildChild = true
+			n = child
+			n.priority++
+
+			if len(wildcard) < len(path) {
+				path = path[len(wildcard):]
+				child := &node{priority: 1}
+				n.children = []*node{child}
+				n = child
+				continue
+			}
+
+			n.handler = h
+			return
+		}
+
+		// catch-all
+		if i+len(wildcard) != len(path) {
+			panic("bolt: catch-all routes are only allowed at the end of the path in path '" + fullPath + "'")
+		}
+		if len(n.path) > 0 && n.path[len(n.path)-1] == '/' {
+			panic("bolt: catch-all conflicts with existing handler for the path segment root in path '" + fullPath + "'")
+		}
+
+		i--
+		if path[i] != '/' {
+			panic("bolt: no / before catch-all in path '" + fullPath + "'")
+		}
+
+		n.path = path[:i]
+
+		catchAllChild := &node{wildChild: true, nType: catchAllNode}
+		n.children = []*node{catchAllChild}
+		n.indices = "/"
+		n = catchAllChild
+		n.priority++
+
+		n.children = []*node{{
+			path:     path[i:],
+			nType:    catchAllNode,
+			handler:  h,
+			priority: 1,
+		}}
+		return
+	}
+
+	n.path = path
+	n.handler = h
+}
+
+// findWildcard returns the leading ":name"/"*name" segment in path, its
+// start index, and whether it's validly formed (no nested wildcards).
+func findWildcard(path string) (wildcard string, i int, valid bool) {
+	for start := 0; start < len(path); start++ {
+		c := path[start]
+		if c != ':' && c != '*' {
+			continue
+		}
+
+		valid = true
+		for end := start + 1; end < len(path); end++ {
+			switch path[end] {
+			case '/':
+				return path[start:end], start, valid
+			case ':', '*':
+				valid = false
+			}
+		}
+		return path[start:], start, valid
+	}
+	return "", -1, false
+}
+
+// Find looks up the handler for method and path, returning a Context
+// acquired from the pool with its params populated.
+func (r *router) Find(method, path string) (HandlerFunc, *Context, Status) {
+	c := r.bolt.pool.Get().(*Context)
+	c.params = c.params[:0]
+
+	root := r.trees[method]
+	if root == nil {
+		if len(r.Allowed(path)) > 0 {
+			return nil, c, NotAllowed
+		}
+		return nil, c, NotFound
+	}
+
+	h, params, _ := root.getValue(path, c.params)
+	if h == nil {
+		if len(r.Allowed(path)) > 0 {
+			return nil, c, NotAllowed
+		}
+		return nil, c, NotFound
+	}
+	c.params = params
+	return h, c, Found
+}
+
+// getValue walks the tree looking for path, appending matched params and
+// reporting via tsr whether the only thing stopping a match was a missing
+// or extra trailing slash.
+func (n *node) getValue(path string, params Params) (handler HandlerFunc, out Params, tsr bool) {
+	if len(path) > len(n.path) {
+		if path[:len(n.path)] != n.path {
+			return nil, params, false
+		}
+		path = path[len(n.path):]
+
+		if !n.wildChild {
+			c := path[0]
+			for i := 0; i < len(n.indices); i++ {
+				if n.indices[i] == c {
+					return n.children[i].getValue(path, params)
+				}
+			}
+			// Trailing slash redirect candidate: no child matched, but
+			// path is exactly "/" and this node has a handler.
+			return nil, params, path == "/" && n.handler != nil
+		}
+
+		child := n.children[0]
+		switch child.nType {
+		case paramNode:
+			end := 0
+			for end < len(path) && path[end] != '/' {
+				end++
+			}
+			params = append(params, Param{Key: child.path[1:], Value: path[:end]})
+
+			if end < len(path) {
+				if len(child.children) > 0 {
+					return child.children[0].getValue(path[end:], params)
+				}
+				return nil, params, path[end:] == "/"
+			}
+			if child.handler != nil {
+				return child.handler, params, false
+			}
+			if len(child.children) > 0 {
+				if tail := child.children[0]; tail.path == "/" && tail.handler != nil {
+					return nil, params, true
+				}
+			}
+			return nil, params, false
+
+		case catchAllNode:
+			// child is the leaf holding the "*name" segment itself; the
+			// matched value includes the leading slash, matching
+			// httprouter's convention.
+			params = append(params, Param{Key: child.path[2:], Value: path})
+			return child.handler, params, false
+		}
+
+		return nil, params, false
+	}
+
+	if path == n.path {
+		if n.handler != nil {
+			return n.handler, params, false
+		}
+		// Check whether a child reachable by adding a trailing slash has a
+		// handler, to offer a trailing-slash redirect.
+		for i, c := range []byte(n.indices) {
+			if c == '/' {
+				child := n.children[i]
+				if (len(child.path) == 1 && child.handler != nil) ||
+					(child.nType == catchAllNode && child.children[0].handler != nil) {
+					return nil, params, true
+				}
+			}
+		}
+		return nil, params, false
+	}
+
+	// Path is one byte shorter than this node's prefix and differs only by
+	// a trailing slash: offer a redirect.
+	if len(path)+1 == len(n.path) && n.path[len(path)] == '/' &&
+		path == n.path[:len(path)] && n.handler != nil {
+		return nil, params, true
+	}
+
+	return nil, params, false
+}
+
+// redirectTrailingSlash reports whether toggling the trailing slash on path
+// resolves to a registered route in method's tree, returning the canonical
+// path to redirect to.
+func (r *router) redirectTrailingSlash(method, path string) (string, bool) {
+	root := r.trees[method]
+	if root == nil {
+		return "", false
+	}
+
+	if path != "/" && strings.HasSuffix(path, "/") {
+		trimmed := strings.TrimSuffix(path, "/")
+		if h, _, _ := root.getValue(trimmed, nil); h != nil {
+			return trimmed, true
+		}
+		return "", false
+	}
+
+	withSlash := path + "/"
+	if h, _, _ := root.getValue(withSlash, nil); h != nil {
+		return withSlash, true
+	}
+	return "", false
+}
+
+// redirectFixedPath collapses repeated slashes in path and walks method's
+// tree case-insensitively, returning the canonical path of the first match
+// found to redirect to.
+func (r *router) redirectFixedPath(method, path string) (string, bool) {
+	root := r.trees[method]
+	if root == nil {
+		return "", false
+	}
+
+	cleaned := path
+	for strings.Contains(cleaned, "//") {
+		cleaned = strings.Replace(cleaned, "//", "/", -1)
+	}
+
+	fixed, ok := root.findCaseInsensitive(cleaned)
+	if !ok || fixed == path {
+		return "", false
+	}
+	return fixed, true
+}
+
+func (n *node) findCaseInsensitive(path string) (string, bool) {
+	return n.matchCI(path)
+}
+
+// matchCI mirrors getValue but compares static segments case-insensitively
+// and tolerates a missing/extra trailing slash, returning the corrected
+// path, with the tree's own spelling of each static segment, on success.
+func (n *node) matchCI(path string) (string, bool) {
+	lowerPath, lowerNPath := strings.ToLower(path), strings.ToLower(n.path)
+
+	if len(path) >= len(n.path) {
+		if !strings.HasPrefix(lowerPath, lowerNPath) {
+			return "", false
+		}
+		rest := path[len(n.path):]
+
+		if rest == "" {
+			if n.handler != nil {
+				return n.path, true
+			}
+			return "", false
+		}
+
+		if !n.wildChild {
+			for _, c := range n.children {
+				tail, ok := c.matchCI(rest)
+				if ok {
+					return n.path + tail, true
+				}
+			}
+			if rest == "/" && n.handler != nil {
+				return n.path, true
+			}
+			return "", false
+		}
+
+		child := n.children[0]
+		switch child.nType {
+		case paramNode:
+			end := 0
+			for end < len(rest) && rest[end] != '/' {
+				end++
+			}
+			if end < len(rest) && len(child.children) > 0 {
+				tail, ok := child.children[0].matchCI(rest[end:])
+				if !ok {
+					return "", false
+				}
+				return n.path + rest[:end] + tail, true
+			}
+			if end == len(rest) && child.handler != nil {
+				return n.path + rest, true
+			}
+			return "", false
+		case catchAllNode:
+			// child is the leaf holding the "*name" segment itself, as in
+			// getValue's catchAllNode arm.
+			if child.handler != nil {
+				return n.path + rest, true
+			}
+		}
+		return "", false
+	}
+
+	if strings.HasPrefix(lowerNPath, lowerPath) && lowerNPath[len(lowerPath):] == "/" && n.handler != nil {
+		return n.path, true
+	}
+
+	return "", false
+}
+
+// Allowed returns the sorted list of methods registered for path, across all
+// of the router's method trees. It is used to build the Allow header on a
+// 405 response and to answer automatic OPTIONS requests.
+func (r *router) Allowed(path string) []string {
+	var methods []string
+	for m, root := range r.trees {
+		if h, _, _ := root.getValue(path, nil); h != nil {
+			methods = append(methods, m)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
@@ -0,0 +1,247 @@
+package bolt
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestBolt() *Bolt {
+	return New()
+}
+
+func TestRouterStaticAndParamRoutes(t *testing.T) {
+	b := newTestBolt()
+
+	var got string
+	b.Get("/users/:id", func(c *Context) {
+		got = c.Param("id")
+	})
+	b.Get("/search", func(c *Context) {
+		got = "search"
+	})
+	b.Get("/files/*filepath", func(c *Context) {
+		got = c.Param("filepath")
+	})
+
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"/users/42", "42"},
+		{"/search", "search"},
+		// The catch-all param value includes the leading slash, matching
+		// httprouter's convention.
+		{"/files/a/b/c.txt", "/a/b/c.txt"},
+	}
+
+	for _, tc := range cases {
+		got = ""
+		req := httptest.NewRequest("GET", tc.path, nil)
+		rw := httptest.NewRecorder()
+		b.ServeHTTP(rw, req)
+		if got != tc.want {
+			t.Errorf("path %q: got param %q, want %q", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestRouterWildcardConflictPanics(t *testing.T) {
+	b := newTestBolt()
+	b.Get("/users/:id", func(c *Context) {})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic registering a conflicting wildcard name")
+		}
+	}()
+	b.Get("/users/:name", func(c *Context) {})
+}
+
+func TestRouterMethodNotAllowedSetsAllowHeader(t *testing.T) {
+	b := newTestBolt()
+	b.Get("/widgets", func(c *Context) {})
+	b.Post("/widgets", func(c *Context) {})
+
+	req := httptest.NewRequest("DELETE", "/widgets", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Code != 405 {
+		t.Fatalf("got status %d, want 405", rw.Code)
+	}
+	if allow := rw.Header().Get(HeaderAllow); allow != "GET, POST" {
+		t.Errorf("Allow header = %q, want %q", allow, "GET, POST")
+	}
+}
+
+func TestRouterAutomaticOPTIONS(t *testing.T) {
+	b := newTestBolt()
+	b.Get("/widgets", func(c *Context) {})
+	b.Post("/widgets", func(c *Context) {})
+
+	req := httptest.NewRequest("OPTIONS", "/widgets", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Code != 200 {
+		t.Fatalf("got status %d, want 200", rw.Code)
+	}
+	if allow := rw.Header().Get(HeaderAllow); allow != "GET, POST, OPTIONS" {
+		t.Errorf("Allow header = %q, want %q", allow, "GET, POST, OPTIONS")
+	}
+}
+
+func TestRouterRedirectTrailingSlash(t *testing.T) {
+	b := newTestBolt()
+	b.Get("/users", func(c *Context) {})
+
+	req := httptest.NewRequest("GET", "/users/", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Code != 301 {
+		t.Fatalf("got status %d, want 301", rw.Code)
+	}
+	if loc := rw.Header().Get("Location"); loc != "/users" {
+		t.Errorf("Location = %q, want %q", loc, "/users")
+	}
+}
+
+func TestRouterRedirectFixedPath(t *testing.T) {
+	b := newTestBolt()
+	b.Get("/Users", func(c *Context) {})
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Code != 301 {
+		t.Fatalf("got status %d, want 301", rw.Code)
+	}
+	if loc := rw.Header().Get("Location"); loc != "/Users" {
+		t.Errorf("Location = %q, want %q", loc, "/Users")
+	}
+}
+
+func TestRouterRedirectFixedPathAgainstCatchAll(t *testing.T) {
+	b := newTestBolt()
+	b.Get("/Files/*filepath", func(c *Context) {})
+
+	// Regression test: matchCI used to re-index into the catch-all leaf's
+	// (nonexistent) children, panicking instead of redirecting.
+	fixed, ok := b.Router.redirectFixedPath("GET", "/files/a/B/c.TXT")
+	if !ok {
+		t.Fatal("expected a fixed-path match against the catch-all route")
+	}
+	if want := "/Files/a/B/c.TXT"; fixed != want {
+		t.Errorf("fixed path = %q, want %q", fixed, want)
+	}
+
+	req := httptest.NewRequest("GET", "/files/a/B/c.TXT", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Code != 301 {
+		t.Fatalf("got status %d, want 301", rw.Code)
+	}
+	if loc := rw.Header().Get("Location"); loc != "/Files/a/B/c.TXT" {
+		t.Errorf("Location = %q, want %q", loc, "/Files/a/B/c.TXT")
+	}
+}
+
+// githubAPI is a representative sample of the github.com/go-chi/chi-style
+// route tables commonly used to benchmark httprouter-family routers: a mix
+// of static segments and named params, enough to exercise tree branching.
+var githubAPI = []struct {
+	method string
+	path   string
+}{
+	{"GET", "/users/:user/repos"},
+	{"GET", "/repos/:owner/:repo"},
+	{"GET", "/repos/:owner/:repo/commits"},
+	{"GET", "/repos/:owner/:repo/commits/:sha"},
+	{"GET", "/repos/:owner/:repo/issues"},
+	{"GET", "/repos/:owner/:repo/issues/:number"},
+	{"POST", "/repos/:owner/:repo/issues/:number/comments"},
+	{"GET", "/orgs/:org/repos"},
+	{"GET", "/orgs/:org/members"},
+	{"GET", "/search/repositories"},
+	{"GET", "/search/issues"},
+	{"GET", "/user"},
+	{"GET", "/user/repos"},
+	{"GET", "/user/orgs"},
+}
+
+// gplusAPI is a small static/param mix modeled on the Google+ API route
+// table, another common httprouter benchmark fixture.
+var gplusAPI = []struct {
+	method string
+	path   string
+}{
+	{"GET", "/people/:userId"},
+	{"GET", "/people/:userId/activities"},
+	{"GET", "/activities/:activityId"},
+	{"GET", "/activities/:activityId/comments"},
+	{"GET", "/activities/:activityId/people/:collection"},
+	{"GET", "/people"},
+	{"GET", "/moments/:collection"},
+}
+
+var staticRoutes = []string{
+	"/", "/cmd.html", "/code.html", "/contrib.html", "/contribute.html",
+	"/debugging_with_gdb.html", "/docs.html", "/effective_go.html",
+	"/files.log", "/gccgo_contribute.html", "/gccgo_install.html",
+	"/go1.1.html", "/go1.2.html", "/go1.html", "/go_faq.html",
+	"/go_mem.html", "/go_spec.html", "/help.html", "/ie.css",
+	"/install-source.html", "/install.html", "/logo-153x55.png",
+	"/Makefile", "/root.html", "/share.png", "/sieve.gif",
+	"/tos.html", "/articles", "/articles/go_command.html",
+}
+
+func buildRouter(routes []struct {
+	method string
+	path   string
+}) *router {
+	b := New()
+	for _, rt := range routes {
+		b.Handle(rt.method, rt.path, []HandlerFunc{func(c *Context) {}})
+	}
+	return b.Router
+}
+
+func BenchmarkRouterStaticRoutes(b *testing.B) {
+	bolt := New()
+	for _, p := range staticRoutes {
+		bolt.Get(p, func(c *Context) {})
+	}
+	r := bolt.Router
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Find("GET", staticRoutes[i%len(staticRoutes)])
+	}
+}
+
+func BenchmarkRouterGithubAPI(b *testing.B) {
+	r := buildRouter(githubAPI)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rt := githubAPI[i%len(githubAPI)]
+		path := strings.NewReplacer(":owner", "labstack", ":repo", "echo", ":user", "labstack",
+			":org", "labstack", ":number", "1", ":sha", "deadbeef").Replace(rt.path)
+		r.Find(rt.method, path)
+	}
+}
+
+func BenchmarkRouterGPlusAPI(b *testing.B) {
+	r := buildRouter(gplusAPI)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rt := gplusAPI[i%len(gplusAPI)]
+		path := strings.NewReplacer(":userId", "1", ":activityId", "1", ":collection", "public").Replace(rt.path)
+		r.Find(rt.method, path)
+	}
+}
@@ -0,0 +1,75 @@
+package bolt
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRunAndStopRoundTrip(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve a port: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	shutdownCalled := make(chan struct{})
+	b := New()
+	b.OnShutdown(func() {
+		close(shutdownCalled)
+	})
+	b.Get("/", func(c *Context) {
+		c.String(200, "ok")
+	})
+
+	done := make(chan struct{})
+	go func() {
+		b.Run(addr)
+		close(done)
+	}()
+
+	// Wait for the server to come up.
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = http.Get("http://" + addr + "/")
+		if err == nil {
+			resp.Body.Close()
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("server never came up: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("got status %d, want 200", resp.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := b.Stop(ctx); err != nil {
+		t.Fatalf("Stop returned %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after Stop")
+	}
+
+	select {
+	case <-shutdownCalled:
+	case <-time.After(time.Second):
+		t.Error("OnShutdown hook was not called")
+	}
+}
+
+func TestStopWithoutRunIsANoop(t *testing.T) {
+	b := New()
+	if err := b.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop on a server that was never Run returned %v, want nil", err)
+	}
+}
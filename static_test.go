@@ -0,0 +1,29 @@
+package bolt
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticServesFilesUnderRoot(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hi there"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+
+	b := New()
+	b.Static("/assets", dir)
+
+	req := httptest.NewRequest("GET", "/assets/hello.txt", nil)
+	rw := httptest.NewRecorder()
+	b.ServeHTTP(rw, req)
+
+	if rw.Code != 200 {
+		t.Fatalf("got status %d, want 200", rw.Code)
+	}
+	if got := rw.Body.String(); got != "hi there" {
+		t.Errorf("body = %q, want %q", got, "hi there")
+	}
+}